@@ -0,0 +1,79 @@
+package neo4go
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+/*
+Configuration of a connection to a Neo4j database.
+
+Zero values fall back to the "bolt" scheme, no authentication and the driver's pool defaults;
+Address has no default and has to be set explicitly.
+*/
+type ConnectionConfig struct {
+	Scheme                       string           // URI scheme, e.g. "bolt", "bolt+routing", "neo4j", "neo4j+s". Defaults to "bolt".
+	Address                      string           // Address of the database (port 7687 assumed if not specified).
+	Auth                         neo4j.AuthToken  // Authentication token. Defaults to neo4j.NoAuth().
+	TLSConfig                    *tls.Config      // Custom TLS configuration, used only with a secure scheme (e.g. "neo4j+s", "bolt+s").
+	Database                     string           // Target database. Defaults to the server's default database.
+	MaxConnPoolSize              int              // Maximum size of the connection pool. Zero means driver default.
+	ConnectionAcquisitionTimeout time.Duration    // Timeout for acquiring a connection from the pool. Zero means driver default.
+	Context                      context.Context  // Context the connection and its session are bound to. Defaults to context.Background().
+}
+
+/*
+Creates a new Neo4j connection using the given configuration.
+Unlike NewConnection, this allows connecting to causal clusters and Aura
+(via "neo4j://"/"neo4j+s://"/"bolt+routing://" schemes), picking a non-default database,
+and tuning the connection pool.
+
+Parameters:
+  - config - configuration of the connection.
+
+Returns:
+  - pointer to the created connection,
+  - error if any occurred.
+*/
+func NewConnectionWithConfig(config ConnectionConfig) (Connection, error) {
+
+	scheme := config.Scheme
+	if scheme == "" {
+		scheme = "bolt"
+	}
+
+	auth := config.Auth
+	if auth.Tokens == nil {
+		auth = neo4j.NoAuth()
+	}
+
+	ctx := config.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	uri := fmt.Sprintf("%s://%s", scheme, config.Address)
+
+	driver, err := neo4j.NewDriverWithContext(uri, auth, func(c *neo4j.Config) {
+		if config.MaxConnPoolSize > 0 {
+			c.MaxConnectionPoolSize = config.MaxConnPoolSize
+		}
+		if config.ConnectionAcquisitionTimeout > 0 {
+			c.ConnectionAcquisitionTimeout = config.ConnectionAcquisitionTimeout
+		}
+		if config.TLSConfig != nil {
+			c.TlsConfig = config.TLSConfig
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	session := driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: config.Database})
+	return &connection{driver, ctx, session, config.Database}, nil
+
+}
@@ -0,0 +1,162 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+Pattern a migration file name has to match, e.g. "0001_init.up.cypher" or "0001_init.down.cypher".
+*/
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.cypher$`)
+
+/*
+One versioned migration, assembled from its up and down Cypher files.
+*/
+type migration struct {
+	version int
+	name    string
+	up      string
+	down    string
+}
+
+/*
+Splits the content of a migration file into individual Cypher statements.
+
+Parameters:
+  - content - raw content of the migration file.
+
+Returns:
+  - non-empty, trimmed statements, in order.
+*/
+func splitStatements(content string) []string {
+	var statements []string
+	for _, statement := range strings.Split(content, ";") {
+		statement = strings.TrimSpace(statement)
+		if statement != "" {
+			statements = append(statements, statement)
+		}
+	}
+	return statements
+}
+
+/*
+Loads and pairs up all migration files found in the given directory.
+
+Parameters:
+  - dir - directory containing the "NNNN_name.up.cypher"/"NNNN_name.down.cypher" pairs.
+
+Returns:
+  - migrations sorted by version,
+  - error if any occurred, including a missing half of a pair.
+*/
+func loadMigrations(dir string) ([]migration, error) {
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*migration)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("migrate: invalid version in file %q: %w", entry.Name(), err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{version: version, name: match[2]}
+			byVersion[version] = m
+		}
+
+		if match[3] == "up" {
+			m.up = string(content)
+		} else {
+			m.down = string(content)
+		}
+	}
+
+	versions := make([]int, 0, len(byVersion))
+	for version := range byVersion {
+		versions = append(versions, version)
+	}
+	sort.Ints(versions)
+
+	migrations := make([]migration, 0, len(versions))
+	for _, version := range versions {
+		m := byVersion[version]
+		if m.up == "" || m.down == "" {
+			return nil, fmt.Errorf("migrate: migration %d (%s) is missing its up or down file", m.version, m.name)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	return migrations, nil
+
+}
+
+/*
+Finds the next migration to apply when moving forward from the given applied version.
+migrations must be sorted by version, as returned by loadMigrations.
+
+Parameters:
+  - migrations - migrations sorted by version,
+  - version - currently applied version.
+
+Returns:
+  - index of the first migration with a version greater than version,
+    or len(migrations) if version is already the latest.
+*/
+func nextMigrationIndex(migrations []migration, version int) int {
+	for i, m := range migrations {
+		if m.version > version {
+			return i
+		}
+	}
+	return len(migrations)
+}
+
+/*
+Finds the migration that was applied last to reach the given version, so it can be reverted.
+migrations must be sorted by version, as returned by loadMigrations.
+
+Parameters:
+  - migrations - migrations sorted by version,
+  - version - currently applied version.
+
+Returns:
+  - index of the migration whose version equals version,
+    or -1 if version is 0 or does not match any migration.
+*/
+func appliedMigrationIndex(migrations []migration, version int) int {
+	if version == 0 {
+		return -1
+	}
+	for i, m := range migrations {
+		if m.version == version {
+			return i
+		}
+	}
+	return -1
+}
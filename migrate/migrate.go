@@ -0,0 +1,344 @@
+/*
+Package migrate provides versioned Cypher schema migrations for neo4go, modelled after
+the workflow golang-migrate provides for SQL drivers.
+
+Migrations are plain Cypher files living in a directory, paired up by version:
+
+	0001_init.up.cypher
+	0001_init.down.cypher
+	0002_add_email.up.cypher
+	0002_add_email.down.cypher
+
+Each file is split on ";" into individual statements. Applied versions are tracked in the
+database itself, under a dedicated label (default "SchemaMigration"), together with a lock
+node used to serialize concurrent migrators.
+*/
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DanielSvub/anytype"
+	"github.com/DanielSvub/neo4go"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Default label used to track applied migrations when Config.Label is empty.
+const defaultLabel = "SchemaMigration"
+
+// ErrLocked is returned when a migrator cannot acquire the migration lock.
+var ErrLocked = errors.New("migrate: database is locked by another migrator")
+
+// ErrDirty is returned when Up/Down/Steps is attempted while a previous migration failed
+// and left the database in a dirty state. Call Force to recover.
+var ErrDirty = errors.New("migrate: database is in a dirty state, call Force to recover")
+
+/*
+Configuration of a Migrator.
+*/
+type Config struct {
+	Label           string // Label used to track applied migrations, defaults to "SchemaMigration".
+	UseTransactions bool   // Whether to run all statements of a single migration file in one write transaction.
+}
+
+/*
+Runs versioned Cypher migrations against a Neo4j database.
+*/
+type Migrator struct {
+	conn       neo4go.Connection
+	label      string // Backtick-quoted, ready to interpolate into a query.
+	lockLabel  string // Backtick-quoted, ready to interpolate into a query.
+	useTx      bool
+	migrations []migration
+}
+
+/*
+Creates a new migrator, loading the migration files from the given directory.
+
+Parameters:
+  - conn - connection to the database,
+  - dir - directory containing the migration files,
+  - config - configuration of the migrator.
+
+Returns:
+  - created migrator,
+  - error if any occurred, including an invalid Config.Label.
+*/
+func New(conn neo4go.Connection, dir string, config Config) (*Migrator, error) {
+
+	label := config.Label
+	if label == "" {
+		label = defaultLabel
+	}
+
+	quotedLabel, err := neo4go.QuoteIdentifier(label)
+	if err != nil {
+		return nil, err
+	}
+	quotedLockLabel, err := neo4go.QuoteIdentifier(label + "Lock")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := loadMigrations(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		conn:       conn,
+		label:      quotedLabel,
+		lockLabel:  quotedLockLabel,
+		useTx:      config.UseTransactions,
+		migrations: migrations,
+	}, nil
+
+}
+
+/*
+Reports the currently applied version of the schema.
+
+Returns:
+  - applied version (0 if no migration has been applied yet),
+  - whether the database is in a dirty state,
+  - error if any occurred.
+*/
+func (ego *Migrator) Version() (int, bool, error) {
+
+	result, err := ego.conn.Query(`
+		MERGE (s:`+ego.label+` {id: 0})
+		ON CREATE SET s.version = 0, s.dirty = false
+		RETURN s.version AS version, s.dirty AS dirty
+	`, nil)
+	if err != nil {
+		return 0, false, err
+	}
+
+	row := result.GetObject(0)
+	return row.GetInt("version"), row.GetBool("dirty"), nil
+
+}
+
+/*
+Forces the tracked version to v and clears the dirty flag, without running any migration.
+Use this to recover after a migration failed and left the database dirty.
+
+Parameters:
+  - v - version to force.
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *Migrator) Force(v int) error {
+	_, err := ego.conn.Query(`
+		MERGE (s:`+ego.label+` {id: 0})
+		SET s.version = $version, s.dirty = false
+	`, anytype.NewObject("version", v))
+	return err
+}
+
+/*
+Applies all migrations that have not been applied yet.
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *Migrator) Up() error {
+	return ego.Steps(len(ego.migrations))
+}
+
+/*
+Reverts all applied migrations, bringing the schema back to version 0.
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *Migrator) Down() error {
+	return ego.Steps(-len(ego.migrations))
+}
+
+/*
+Applies or reverts up to n migrations. Positive n moves forward, negative n moves backward;
+n is clamped to the amount of migrations actually available in that direction.
+
+Parameters:
+  - n - amount and direction of the steps to take.
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *Migrator) Steps(n int) error {
+
+	if n == 0 {
+		return nil
+	}
+
+	version, dirty, err := ego.Version()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return ErrDirty
+	}
+
+	if err := ego.acquireLock(); err != nil {
+		return err
+	}
+	defer ego.releaseLock()
+
+	if n > 0 {
+		for i := 0; i < n; i++ {
+			index := nextMigrationIndex(ego.migrations, version)
+			if index >= len(ego.migrations) {
+				break
+			}
+			m := ego.migrations[index]
+			if err := ego.apply(m, m.up, m.version); err != nil {
+				return err
+			}
+			version = m.version
+		}
+		return nil
+	}
+
+	for i := 0; i < -n; i++ {
+		index := appliedMigrationIndex(ego.migrations, version)
+		if index < 0 {
+			break
+		}
+		m := ego.migrations[index]
+		previous := 0
+		if index > 0 {
+			previous = ego.migrations[index-1].version
+		}
+		if err := ego.apply(m, m.down, previous); err != nil {
+			return err
+		}
+		version = previous
+	}
+	return nil
+
+}
+
+/*
+Runs the statements of a single migration file and updates the tracked version on success,
+marking the database dirty for the duration of the run.
+
+Parameters:
+  - m - migration being applied,
+  - content - Cypher content to run (the up or down side of m),
+  - newVersion - version to record once the migration succeeds.
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *Migrator) apply(m migration, content string, newVersion int) error {
+
+	if _, err := ego.conn.Query(`
+		MERGE (s:`+ego.label+` {id: 0})
+		SET s.dirty = true
+	`, nil); err != nil {
+		return fmt.Errorf("migrate: marking migration %d (%s) dirty: %w", m.version, m.name, err)
+	}
+
+	statements := splitStatements(content)
+
+	if err := ego.runStatements(statements); err != nil {
+		return fmt.Errorf("migrate: applying migration %d (%s): %w", m.version, m.name, err)
+	}
+
+	_, err := ego.conn.Query(`
+		MERGE (s:`+ego.label+` {id: 0})
+		SET s.version = $version, s.dirty = false, s.appliedAt = $appliedAt
+	`, anytype.NewObject("version", newVersion, "appliedAt", time.Now().UTC().Format(time.RFC3339)))
+	if err != nil {
+		return fmt.Errorf("migrate: recording migration %d (%s): %w", m.version, m.name, err)
+	}
+
+	return nil
+
+}
+
+/*
+Runs the given statements, either all within a single write transaction or one by one,
+depending on Config.UseTransactions.
+
+Parameters:
+  - statements - Cypher statements to run, in order.
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *Migrator) runStatements(statements []string) error {
+
+	if !ego.useTx {
+		for _, statement := range statements {
+			if _, err := ego.conn.Query(statement, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return ego.conn.WithTransaction(func(tx neo4j.ManagedTransaction) error {
+		for _, statement := range statements {
+			if _, err := tx.Run(context.Background(), statement, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+}
+
+/*
+Acquires the migration lock, relying on Neo4j's write lock on the lock node to serialize
+concurrent migrators.
+
+Returns:
+  - error if any occurred, including ErrLocked if another migrator is currently running.
+*/
+func (ego *Migrator) acquireLock() error {
+	return ego.conn.WithTransaction(func(tx neo4j.ManagedTransaction) error {
+
+		result, err := tx.Run(context.Background(), `
+			MERGE (l:`+ego.lockLabel+` {id: 0})
+			ON CREATE SET l.locked = false
+			RETURN l.locked AS locked
+		`, nil)
+		if err != nil {
+			return err
+		}
+
+		record, err := result.Single(context.Background())
+		if err != nil {
+			return err
+		}
+
+		locked, _ := record.Get("locked")
+		if locked == true {
+			return ErrLocked
+		}
+
+		_, err = tx.Run(context.Background(), `
+			MATCH (l:`+ego.lockLabel+` {id: 0})
+			SET l.locked = true
+		`, nil)
+		return err
+
+	})
+}
+
+/*
+Releases the migration lock acquired by acquireLock.
+*/
+func (ego *Migrator) releaseLock() error {
+	_, err := ego.conn.Query(`
+		MATCH (l:`+ego.lockLabel+` {id: 0})
+		SET l.locked = false
+	`, nil)
+	return err
+}
@@ -2,14 +2,12 @@ package neo4go
 
 import (
 	"context"
-	"fmt"
 	"io"
 	"strings"
 
 	"github.com/DanielSvub/anytype"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
-	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
 )
 
 /*
@@ -21,7 +19,15 @@ Extends:
 type Connection interface {
 	io.Closer
 	Query(query string, params anytype.Object) (anytype.List, error)
+	QueryContext(ctx context.Context, query string, params anytype.Object) (anytype.List, error)
+	QueryStream(ctx context.Context, query string, params anytype.Object) (ResultIterator, error)
+	QueryChan(ctx context.Context, query string, params anytype.Object) (<-chan anytype.Object, <-chan error, error)
 	NewCollection(entity string) (Collection, error)
+	NewRelationshipCollection(kind string, from Collection, to Collection) (RelationshipCollection, error)
+	WithTransaction(work func(tx neo4j.ManagedTransaction) error) error
+	WithReadOnly() ReadQuerier
+	Session() neo4j.SessionWithContext
+	NewSession(config neo4j.SessionConfig) neo4j.SessionWithContext
 }
 
 /*
@@ -31,13 +37,15 @@ Implements:
   - Connector.
 */
 type connection struct {
-	driver  neo4j.DriverWithContext
-	ctx     context.Context
-	session neo4j.SessionWithContext
+	driver   neo4j.DriverWithContext
+	ctx      context.Context
+	session  neo4j.SessionWithContext
+	database string
 }
 
 /*
-Creates a new Neo4j connection.
+Creates a new Neo4j connection over plain "bolt://" with basic authentication.
+For clusters, Aura, custom TLS or database selection, use NewConnectionWithConfig instead.
 
 Parameters:
   - address - address of the database (port 7687 assumed if not specified),
@@ -49,13 +57,10 @@ Returns:
   - error if any occurred.
 */
 func NewConnection(address string, user string, password string) (Connection, error) {
-	driver, err := neo4j.NewDriverWithContext(fmt.Sprintf("bolt://%s", address), neo4j.BasicAuth(user, password, ""))
-	if err != nil {
-		return nil, err
-	}
-	ctx := context.Background()
-	session := driver.NewSession(ctx, neo4j.SessionConfig{})
-	return &connection{driver, ctx, session}, nil
+	return NewConnectionWithConfig(ConnectionConfig{
+		Address: address,
+		Auth:    neo4j.BasicAuth(user, password, ""),
+	})
 }
 
 /*
@@ -83,6 +88,143 @@ Returns:
   - error if any occurred.
 */
 func (ego *connection) Query(query string, params anytype.Object) (anytype.List, error) {
+	return ego.QueryContext(ego.ctx, query, params)
+}
+
+/*
+Performs a query over the Neo4j database, bound to the given context instead of the
+connection's own background context. Use this to cancel a slow query or attach a deadline.
+
+Parameters:
+  - ctx - context the query is bound to,
+  - query - text of the query in Cypher,
+  - params - object containing variables used in the query.
+
+Returns:
+  - list of the query results,
+  - error if any occurred.
+*/
+func (ego *connection) QueryContext(ctx context.Context, query string, params anytype.Object) (anytype.List, error) {
+	return runCypher(ctx, func(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error) {
+		return ego.session.Run(ctx, cypher, params)
+	}, query, params)
+}
+
+/*
+Returns a new session scoped to the connection's target database, using the driver defaults
+otherwise. Bolt sessions are not safe for concurrent use, so concurrent goroutines should
+each get their own session instead of sharing the connection's.
+
+Returns:
+  - created session.
+*/
+func (ego *connection) Session() neo4j.SessionWithContext {
+	return ego.NewSession(neo4j.SessionConfig{DatabaseName: ego.database})
+}
+
+/*
+Returns a new session configured as given. Bolt sessions are not safe for concurrent use,
+so concurrent goroutines should each get their own session instead of sharing the connection's.
+
+Parameters:
+  - config - configuration of the session.
+
+Returns:
+  - created session.
+*/
+func (ego *connection) NewSession(config neo4j.SessionConfig) neo4j.SessionWithContext {
+	if config.DatabaseName == "" {
+		config.DatabaseName = ego.database
+	}
+	return ego.driver.NewSession(ego.ctx, config)
+}
+
+/*
+Interface for running read-only queries through WithReadOnly.
+*/
+type ReadQuerier interface {
+	Query(query string, params anytype.Object) (anytype.List, error)
+	QueryContext(ctx context.Context, query string, params anytype.Object) (anytype.List, error)
+}
+
+/*
+Neo4j connection restricted to read-only queries, routed through ExecuteRead so a causal
+cluster can serve them from a follower instead of the leader.
+*/
+type readOnlyConnection struct {
+	conn *connection
+}
+
+/*
+Returns a view of the connection which routes queries through ExecuteRead,
+making them eligible to run against a cluster follower.
+
+Returns:
+  - read-only view of the connection.
+*/
+func (ego *connection) WithReadOnly() ReadQuerier {
+	return &readOnlyConnection{ego}
+}
+
+/*
+Performs a read-only query over the Neo4j database.
+
+Parameters:
+  - query - text of the query in Cypher,
+  - params - object containing variables used in the query.
+
+Returns:
+  - list of the query results,
+  - error if any occurred.
+*/
+func (ego *readOnlyConnection) Query(query string, params anytype.Object) (anytype.List, error) {
+	return ego.QueryContext(ego.conn.ctx, query, params)
+}
+
+/*
+Performs a read-only query over the Neo4j database, bound to the given context.
+
+Parameters:
+  - ctx - context the query is bound to,
+  - query - text of the query in Cypher,
+  - params - object containing variables used in the query.
+
+Returns:
+  - list of the query results,
+  - error if any occurred.
+*/
+func (ego *readOnlyConnection) QueryContext(ctx context.Context, query string, params anytype.Object) (anytype.List, error) {
+	output, err := ego.conn.session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return runCypher(ctx, tx.Run, query, params)
+	})
+	if err != nil {
+		return anytype.NewList(), err
+	}
+	return output.(anytype.List), nil
+}
+
+/*
+Function capable of running a Cypher query, implemented by a closure over either
+neo4j.SessionWithContext or neo4j.ManagedTransaction. A single interface cannot be satisfied
+by both, since SessionWithContext.Run is variadic and ManagedTransaction.Run is not, so callers
+wrap whichever one they have instead.
+*/
+type cypherRunner func(ctx context.Context, cypher string, params map[string]any) (neo4j.ResultWithContext, error)
+
+/*
+Runs a Cypher query over the given runner and converts the result into an anytype list.
+
+Parameters:
+  - ctx - context of the query,
+  - runner - closure running the query over a session or a transaction,
+  - query - text of the query in Cypher,
+  - params - object containing variables used in the query.
+
+Returns:
+  - list of the query results,
+  - error if any occurred.
+*/
+func runCypher(ctx context.Context, runner cypherRunner, query string, params anytype.Object) (anytype.List, error) {
 
 	var paramDict map[string]any
 	if params != nil {
@@ -91,29 +233,19 @@ func (ego *connection) Query(query string, params anytype.Object) (anytype.List,
 
 	query = strings.TrimSpace(query)
 
-	result, err := ego.session.Run(ego.ctx, query, paramDict)
+	result, err := runner(ctx, query, paramDict)
 	output := anytype.NewList()
 	if err != nil {
 		return output, err
 	}
 
-	for result.Next(ego.ctx) {
+	for result.Next(ctx) {
 		record := result.Record()
 		item := anytype.NewObject()
 		for _, key := range record.Keys {
 			value, ok := record.Get(key)
 			if ok {
-				switch val := value.(type) {
-				case dbtype.Node:
-					item.Set(key, anytype.NewObject(
-						"identity", val.GetId(),
-						"elementId", val.GetElementId(),
-						"labels", val.Labels,
-						"properties", val.GetProperties(),
-					))
-				default:
-					item.Set(key, val)
-				}
+				item.Set(key, convertValue(value))
 			}
 		}
 		output.Add(item)
@@ -126,13 +258,29 @@ func (ego *connection) Query(query string, params anytype.Object) (anytype.List,
 	return output, nil
 }
 
+/*
+Runs a function within a single managed write transaction.
+
+Parameters:
+  - work - function to run; its returned error aborts and rolls back the transaction.
+
+Returns:
+  - error if any occurred, including a rollback triggered by work.
+*/
+func (ego *connection) WithTransaction(work func(tx neo4j.ManagedTransaction) error) error {
+	_, err := ego.session.ExecuteWrite(ego.ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		return nil, work(tx)
+	})
+	return err
+}
+
 /*
 One element of the Neo4j collection.
 
 Promoted fields:
   - anytype.Object
 */
-type node struct {
+type Node struct {
 	*anytype.MapObject
 	col      *collection
 	id       string
@@ -152,8 +300,8 @@ Parameters:
 Returns:
   - pointer to the created node.
 */
-func (ego *collection) newNode(id string, obj anytype.Object) *node {
-	return &node{
+func (ego *collection) newNode(id string, obj anytype.Object) *Node {
+	return &Node{
 		MapObject: obj.(*anytype.MapObject),
 		col:       ego,
 		id:        id,
@@ -164,20 +312,31 @@ func (ego *collection) newNode(id string, obj anytype.Object) *node {
 }
 
 /*
-Creates a template of the node for the query.
+Creates a template of the node for the query, validating and quoting every property key.
 
 Returns:
-  - created template.
+  - created template,
+  - error if any of the property keys is not a valid Cypher identifier.
 */
-func (ego *node) template() (result string) {
+func (ego *Node) template() (result string, err error) {
 	result += "{"
 	i := 0
 	ego.ForEach(func(key string, _ any) {
-		result += key + `:$` + key
+		if err != nil {
+			return
+		}
+		var quoted string
+		if quoted, err = QuoteIdentifier(key); err != nil {
+			return
+		}
+		result += quoted + `:$` + key
 		if i++; i < ego.Count() {
 			result += ","
 		}
 	})
+	if err != nil {
+		return "", err
+	}
 	result += "}"
 	return
 }
@@ -193,7 +352,7 @@ Parameters:
 Returns:
   - updated object (promoted field).
 */
-func (ego *node) Set(values ...any) anytype.Object {
+func (ego *Node) Set(values ...any) anytype.Object {
 	if len(values)%2 == 0 {
 		for i := 0; i < len(values); i += 2 {
 			key, ok := values[i].(string)
@@ -224,7 +383,7 @@ Parameters:
 Returns:
   - updated object (promoted field).
 */
-func (ego *node) Unset(keys ...string) anytype.Object {
+func (ego *Node) Unset(keys ...string) anytype.Object {
 	for _, key := range keys {
 		if ego.added.Contains(key) {
 			ego.added.Delete(ego.added.IndexOf(key))
@@ -244,53 +403,95 @@ func (ego *node) Unset(keys ...string) anytype.Object {
 Refuses to delete all fields in the node and panics.
 Overrides the method of the promoted field.
 */
-func (ego *node) Clear() anytype.Object {
+func (ego *Node) Clear() anytype.Object {
 	panic("Cannot clear a Neo4j node.")
 }
 
 /*
-Commits all changes to the database.
+Applies the pending SET/unset operations of the node within an already running transaction.
+Does not clear the tracking lists itself, since the enclosing transaction function may be retried.
+
+Parameters:
+  - tx - transaction to run the queries in.
 
 Returns:
-  - updated object (promoted field).
+  - error if any occurred.
 */
-func (ego *node) Commit() *node {
+func (ego *Node) commitTx(tx neo4j.ManagedTransaction) error {
+
+	label, err := QuoteIdentifier(ego.col.label)
+	if err != nil {
+		return err
+	}
 
 	// Commiting set keys
 	if !ego.added.Empty() || !ego.modified.Empty() {
 		todo := ego.added.Concat(ego.modified)
-		cypher := `MATCH (n:` + ego.col.label + `) WHERE elementId(n) = "` + ego.id + `" SET` + todo.
-			ReduceStrings("", func(res, key string) string {
-				var comma string
-				if res != "" {
-					comma = ","
-				}
-				return res + comma + ` n.` + key + ` = $` + key
-			})
-		_, err := ego.col.conn.Query(cypher, ego.Pluck(todo.StringSlice()...))
-		if err != nil {
-			panic(err)
+		var set string
+		for _, key := range todo.StringSlice() {
+			quoted, err := QuoteIdentifier(key)
+			if err != nil {
+				return err
+			}
+			if set != "" {
+				set += ","
+			}
+			set += ` n.` + quoted + ` = $` + key
+		}
+		cypher := `MATCH (n:` + label + `) WHERE elementId(n) = $id SET` + set
+		params := ego.Pluck(todo.StringSlice()...).Set("id", ego.id)
+		if _, err := runCypher(ego.col.conn.ctx, tx.Run, cypher, params); err != nil {
+			return err
 		}
-		ego.added.Clear()
 	}
 
 	// Commiting unset keys
 	if !ego.deleted.Empty() {
-		ego.deleted.
-			ForEachString(func(key string) {
-				_, err := ego.col.conn.Query(`
-					MATCH (n:`+ego.col.label+`)
-					WHERE elementId(n) = "`+ego.id+`"
-					SET n.`+key+` = null
-				`, nil)
-				if err != nil {
-					panic(err)
-				}
-			}).
-			Clear()
+		var err error
+		ego.deleted.ForEachString(func(key string) {
+			if err != nil {
+				return
+			}
+			var quoted string
+			if quoted, err = QuoteIdentifier(key); err != nil {
+				return
+			}
+			_, err = runCypher(ego.col.conn.ctx, tx.Run, `
+				MATCH (n:`+label+`)
+				WHERE elementId(n) = $id
+				SET n.`+quoted+` = null
+			`, anytype.NewObject("id", ego.id))
+		})
+		if err != nil {
+			return err
+		}
 	}
 
-	return ego
+	return nil
+}
+
+/*
+Clears the tracking lists of the node after a successful commit.
+*/
+func (ego *Node) clearTracking() {
+	ego.added.Clear()
+	ego.modified.Clear()
+	ego.deleted.Clear()
+}
+
+/*
+Commits all changes to the database in a single managed transaction.
+
+Returns:
+  - updated object (promoted field),
+  - error if any occurred.
+*/
+func (ego *Node) Commit() (*Node, error) {
+	if err := ego.col.conn.WithTransaction(ego.commitTx); err != nil {
+		return ego, err
+	}
+	ego.clearTracking()
+	return ego, nil
 }
 
 /*
@@ -301,7 +502,7 @@ Extends:
 */
 type Collection interface {
 	anytype.List
-	Commit() anytype.List
+	Commit() (anytype.List, error)
 }
 
 /*
@@ -327,11 +528,12 @@ Parameters:
   - entity - label of the entity to get.
 
 Returns:
-  - updated list (promoted field).
+  - updated list (promoted field),
+  - error if any occurred, including entity not being a valid Cypher label.
 */
 func (ego *connection) NewCollection(entity string) (Collection, error) {
 
-	result, err := ego.Query("MATCH (n:"+entity+") RETURN n", nil)
+	label, err := QuoteIdentifier(entity)
 	if err != nil {
 		return nil, err
 	}
@@ -344,10 +546,22 @@ func (ego *connection) NewCollection(entity string) (Collection, error) {
 		deleted:  anytype.NewList(),
 	}
 
-	col.SliceList = result.MapObjects(func(x anytype.Object) any {
-		return col.newNode(x.GetObject("n").GetString("elementId"),
-			x.GetObject("n").GetObject("properties").(*anytype.MapObject))
-	}).(*anytype.SliceList)
+	it, err := ego.QueryStream(ego.ctx, "MATCH (n:"+label+") RETURN n", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	nodes := anytype.NewList()
+	for it.Next() {
+		n := it.Record().GetObject("n")
+		nodes.Add(col.newNode(n.GetString("elementId"), n.GetObject("properties").(*anytype.MapObject)))
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	col.SliceList = nodes.(*anytype.SliceList)
 
 	return col, nil
 
@@ -431,7 +645,7 @@ func (ego *collection) Delete(indexes ...int) anytype.List {
 			if ego.modified.Contains(elem) {
 				ego.modified.Delete(ego.modified.IndexOf(elem))
 			}
-			node, ok := elem.(*node)
+			node, ok := elem.(*Node)
 			if ok {
 				ego.deleted.Add(node.id)
 			}
@@ -466,51 +680,93 @@ func (ego *collection) Clear() anytype.List {
 }
 
 /*
-Commits all changes to the database.
+Commits all changes to the database in a single managed transaction.
+If the transaction fails, the whole batch of adds, deletes and modifications is rolled back
+and the tracking lists are left untouched so the commit can be retried.
 
 Returns:
-  - updated list (promoted field).
+  - updated list (promoted field),
+  - error if any occurred.
 */
-func (ego *collection) Commit() anytype.List {
+func (ego *collection) Commit() (anytype.List, error) {
+
+	label, err := QuoteIdentifier(ego.label)
+	if err != nil {
+		return ego.SliceList, err
+	}
 
-	// Commiting added elements
-	if !ego.added.Empty() {
-		ego.added.
-			ForEachObject(func(x anytype.Object) {
-				template := x.(*node).template()
-				result, err := ego.conn.Query(`CREATE (n:`+ego.label+template+`) RETURN elementId(n)`, x)
+	err = ego.conn.WithTransaction(func(tx neo4j.ManagedTransaction) error {
+
+		var txErr error
+
+		// Commiting added elements
+		if !ego.added.Empty() {
+			ego.added.ForEachObject(func(x anytype.Object) {
+				if txErr != nil {
+					return
+				}
+				n := x.(*Node)
+				template, err := n.template()
 				if err != nil {
-					panic(err)
+					txErr = err
+					return
 				}
-				id := result.GetObject(0).GetString("elementId(n)")
-				x.(*node).id = id
-			}).
-			Clear()
-	}
+				result, err := runCypher(ego.conn.ctx, tx.Run, `CREATE (n:`+label+template+`) RETURN elementId(n)`, n)
+				if err != nil {
+					txErr = err
+					return
+				}
+				n.id = result.GetObject(0).GetString("elementId(n)")
+			})
+			if txErr != nil {
+				return txErr
+			}
+		}
 
-	// Commiting deleted elements
-	if !ego.deleted.Empty() {
-		ego.deleted.
-			ForEachString(func(id string) {
-				_, err := ego.conn.Query(`
-					MATCH (n:`+ego.label+`)
+		// Commiting deleted elements
+		if !ego.deleted.Empty() {
+			ego.deleted.ForEachString(func(id string) {
+				if txErr != nil {
+					return
+				}
+				_, txErr = runCypher(ego.conn.ctx, tx.Run, `
+					MATCH (n:`+label+`)
 					WHERE elementId(n) = $id
 					DELETE n
 				`, anytype.NewObject("id", id))
-				if err != nil {
-					panic(err)
+			})
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		// Commiting modified elements
+		if !ego.modified.Empty() {
+			ego.modified.ForEachObject(func(x anytype.Object) {
+				if txErr != nil {
+					return
 				}
-			}).
-			Clear()
-	}
+				txErr = x.(*Node).commitTx(tx)
+			})
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		return nil
 
-	// Commiting modified elements
-	if !ego.modified.Empty() {
-		ego.modified.
-			ForEachObject(func(x anytype.Object) { x.(*node).Commit() }).
-			Clear()
+	})
+
+	if err != nil {
+		return ego.SliceList, err
 	}
 
-	return ego.SliceList
+	ego.added.Clear()
+	ego.deleted.Clear()
+	ego.modified.
+		ForEachObject(func(x anytype.Object) { x.(*Node).clearTracking() }).
+		Clear()
+
+	return ego.SliceList, nil
 
 }
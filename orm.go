@@ -0,0 +1,248 @@
+package neo4go
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/DanielSvub/anytype"
+)
+
+/*
+Name of the struct tag used to describe how a field of a registered type
+maps onto a node property.
+
+Format:
+  - `neo4j:"name"` - the field is mapped to the property "name",
+  - `neo4j:"name,id"` - the field holds the ElementId of the node; it is not mapped to any
+    property (the name part is ignored), so it is neither written to nor read from the node,
+  - `neo4j:"-"` - the field is ignored.
+
+A field without the tag is mapped under its own name.
+
+Unlike some other struct-tag ORMs, the label of the collection is not taken from the tag:
+there is no `label=` option, the label is always the one passed to NewTypedCollection.
+*/
+const neo4jTag = "neo4j"
+
+/*
+Interface for a typed collection backed by a registered Go struct.
+
+Type parameter T is the struct registered for the collection.
+*/
+type TypedCollection[T any] interface {
+	Count() int
+	Get(index int) T
+	Add(value T) TypedCollection[T]
+	Delete(indexes ...int) TypedCollection[T]
+	Commit() (TypedCollection[T], error)
+}
+
+/*
+Field of a registered struct mapped onto a node property.
+*/
+type ormField struct {
+	index    int
+	property string
+}
+
+/*
+Typed Neo4j collection.
+Wraps a plain Collection and reflects values of the registered struct type
+into node properties on write and back into the struct on read.
+*/
+type typedCollection[T any] struct {
+	col     *collection
+	typ     reflect.Type
+	fields  []ormField
+	idField int // index of the struct field holding the ElementId, -1 if none
+}
+
+/*
+Parses the neo4j tag of a struct field.
+
+Parameters:
+  - field - field to parse.
+
+Returns:
+  - property name the field is mapped to ("" if the field is ignored),
+  - whether the field holds the ElementId of the node.
+*/
+func parseOrmTag(field reflect.StructField) (property string, isId bool) {
+
+	tag, ok := field.Tag.Lookup(neo4jTag)
+	if !ok {
+		return field.Name, false
+	}
+
+	parts := strings.Split(tag, ",")
+	if parts[0] == "-" {
+		return "", false
+	}
+
+	property = parts[0]
+	if property == "" {
+		property = field.Name
+	}
+
+	for _, opt := range parts[1:] {
+		if opt == "id" {
+			isId = true
+		}
+	}
+
+	return
+
+}
+
+/*
+Creates a new typed Neo4j collection.
+
+Parameters:
+  - conn - connection to the database,
+  - label - label of the entity to get.
+
+Returns:
+  - created typed collection,
+  - error if any occurred, including T not being a struct type.
+*/
+func NewTypedCollection[T any](conn Connection, label string) (TypedCollection[T], error) {
+
+	var zero T
+	typ := reflect.TypeOf(zero)
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("neo4go: %T is not a struct type", zero)
+	}
+
+	plain, err := conn.NewCollection(label)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &typedCollection[T]{
+		col:     plain.(*collection),
+		typ:     typ,
+		idField: -1,
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		property, isId := parseOrmTag(typ.Field(i))
+		if isId {
+			result.idField = i
+			continue
+		}
+		if property != "" {
+			result.fields = append(result.fields, ormField{index: i, property: property})
+		}
+	}
+
+	return result, nil
+
+}
+
+/*
+Converts a registered struct to an anytype object containing its mapped properties.
+
+Parameters:
+  - value - struct to convert.
+
+Returns:
+  - created object.
+*/
+func (ego *typedCollection[T]) toObject(value T) anytype.Object {
+	val := reflect.ValueOf(value)
+	obj := anytype.NewObject()
+	for _, field := range ego.fields {
+		obj.Set(field.property, val.Field(field.index).Interface())
+	}
+	return obj
+}
+
+/*
+Scans a node into a new instance of the registered struct.
+
+Parameters:
+  - n - node to scan.
+
+Returns:
+  - filled struct.
+*/
+func (ego *typedCollection[T]) fromNode(n *Node) T {
+	var out T
+	val := reflect.ValueOf(&out).Elem()
+	for _, field := range ego.fields {
+		if n.KeyExists(field.property) {
+			prop := reflect.ValueOf(n.Get(field.property))
+			if prop.IsValid() && prop.Type().ConvertibleTo(val.Field(field.index).Type()) {
+				val.Field(field.index).Set(prop.Convert(val.Field(field.index).Type()))
+			}
+		}
+	}
+	if ego.idField >= 0 {
+		val.Field(ego.idField).Set(reflect.ValueOf(n.id).Convert(val.Field(ego.idField).Type()))
+	}
+	return out
+}
+
+/*
+Returns the number of elements in the collection.
+
+Returns:
+  - count of the elements.
+*/
+func (ego *typedCollection[T]) Count() int {
+	return ego.col.Count()
+}
+
+/*
+Gets the element at the given position in the collection, scanned into the registered struct.
+
+Parameters:
+  - index - position of the element.
+
+Returns:
+  - scanned struct.
+*/
+func (ego *typedCollection[T]) Get(index int) T {
+	return ego.fromNode(ego.col.Get(index).(*Node))
+}
+
+/*
+Adds a new element at the end of the collection.
+
+Parameters:
+  - value - struct to add.
+
+Returns:
+  - updated typed collection.
+*/
+func (ego *typedCollection[T]) Add(value T) TypedCollection[T] {
+	ego.col.Add(ego.toObject(value))
+	return ego
+}
+
+/*
+Deletes the elements at the specified positions in the collection.
+
+Parameters:
+  - indexes... - any amount of positions of the elements to delete.
+
+Returns:
+  - updated typed collection.
+*/
+func (ego *typedCollection[T]) Delete(indexes ...int) TypedCollection[T] {
+	ego.col.Delete(indexes...)
+	return ego
+}
+
+/*
+Commits all changes to the database.
+
+Returns:
+  - updated typed collection,
+  - error if any occurred.
+*/
+func (ego *typedCollection[T]) Commit() (TypedCollection[T], error) {
+	_, err := ego.col.Commit()
+	return ego, err
+}
@@ -0,0 +1,377 @@
+package neo4go
+
+import (
+	"github.com/DanielSvub/anytype"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+/*
+Direction of a relationship traversal.
+*/
+type Direction int
+
+const (
+	Outgoing Direction = iota
+	Incoming
+	Either
+)
+
+/*
+One relationship (edge) of the Neo4j graph.
+
+Promoted fields:
+  - anytype.Object
+*/
+type Relationship struct {
+	*anytype.MapObject
+	col      *relationshipCollection
+	id       string
+	kind     string
+	fromId   string
+	toId     string
+	added    anytype.List
+	modified anytype.List
+	deleted  anytype.List
+}
+
+/*
+Creates a new Neo4j relationship.
+
+Parameters:
+  - id - ElementId of the relationship,
+  - kind - type of the relationship,
+  - fromId - ElementId of the start node,
+  - toId - ElementId of the end node,
+  - obj - content of the relationship.
+
+Returns:
+  - pointer to the created relationship.
+*/
+func (ego *relationshipCollection) newRelationship(id string, kind string, fromId string, toId string, obj anytype.Object) *Relationship {
+	return &Relationship{
+		MapObject: obj.(*anytype.MapObject),
+		col:       ego,
+		id:        id,
+		kind:      kind,
+		fromId:    fromId,
+		toId:      toId,
+		added:     anytype.NewList(),
+		modified:  anytype.NewList(),
+		deleted:   anytype.NewList(),
+	}
+}
+
+/*
+Type of the relationship.
+
+Returns:
+  - relationship type.
+*/
+func (ego *Relationship) Type() string {
+	return ego.kind
+}
+
+/*
+Creates a template of the relationship properties for the query, validating and quoting
+every property key.
+
+Returns:
+  - created template,
+  - error if any of the property keys is not a valid Cypher identifier.
+*/
+func (ego *Relationship) template() (result string, err error) {
+	result += "{"
+	i := 0
+	ego.ForEach(func(key string, _ any) {
+		if err != nil {
+			return
+		}
+		var quoted string
+		if quoted, err = QuoteIdentifier(key); err != nil {
+			return
+		}
+		result += quoted + `:$` + key
+		if i++; i < ego.Count() {
+			result += ","
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	result += "}"
+	return
+}
+
+/*
+Interface for a collection of relationships of a single type between two node labels.
+
+Extends:
+  - anytype.List.
+*/
+type RelationshipCollection interface {
+	anytype.List
+	Link(from *Node, to *Node, props anytype.Object) RelationshipCollection
+	Commit() (anytype.List, error)
+}
+
+/*
+Neo4j relationship collection.
+Allows to create, query and delete edges of a single type and commit the changes to the database,
+analogously to Collection for nodes.
+
+Promoted fields:
+  - anytype.List.
+*/
+type relationshipCollection struct {
+	*anytype.SliceList
+	conn    *connection
+	kind    string
+	fromCol *collection
+	toCol   *collection
+	added   anytype.List // List of relationships
+	deleted anytype.List // List of IDs
+}
+
+/*
+Creates a new Neo4j relationship collection, matching edges of the form
+(a:fromLabel)-[r:kind]->(b:toLabel).
+
+Parameters:
+  - kind - type of the relationship,
+  - from - collection of the start nodes,
+  - to - collection of the end nodes.
+
+Returns:
+  - created relationship collection,
+  - error if any occurred.
+*/
+func (ego *connection) NewRelationshipCollection(kind string, from Collection, to Collection) (RelationshipCollection, error) {
+
+	fromCol := from.(*collection)
+	toCol := to.(*collection)
+
+	quotedKind, err := QuoteIdentifier(kind)
+	if err != nil {
+		return nil, err
+	}
+	fromLabel, err := QuoteIdentifier(fromCol.label)
+	if err != nil {
+		return nil, err
+	}
+	toLabel, err := QuoteIdentifier(toCol.label)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		MATCH (a:` + fromLabel + `)-[r:` + quotedKind + `]->(b:` + toLabel + `)
+		RETURN elementId(r) AS id, elementId(a) AS fromId, elementId(b) AS toId, properties(r) AS props
+	`
+	result, err := ego.Query(query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	col := &relationshipCollection{
+		conn:    ego,
+		kind:    kind,
+		fromCol: fromCol,
+		toCol:   toCol,
+		added:   anytype.NewList(),
+		deleted: anytype.NewList(),
+	}
+
+	col.SliceList = result.MapObjects(func(x anytype.Object) any {
+		return col.newRelationship(
+			x.GetString("id"),
+			kind,
+			x.GetString("fromId"),
+			x.GetString("toId"),
+			x.GetObject("props").(*anytype.MapObject),
+		)
+	}).(*anytype.SliceList)
+
+	return col, nil
+
+}
+
+/*
+Links two already persisted nodes with a new relationship at the end of the collection.
+Named Link rather than Add to avoid overloading the promoted anytype.List.Add.
+
+Parameters:
+  - from - start node,
+  - to - end node,
+  - props - properties of the relationship.
+
+Returns:
+  - updated relationship collection.
+*/
+func (ego *relationshipCollection) Link(from *Node, to *Node, props anytype.Object) RelationshipCollection {
+	if props == nil {
+		props = anytype.NewObject()
+	}
+	rel := ego.newRelationship("", ego.kind, from.id, to.id, props.(*anytype.MapObject))
+	ego.added.Add(rel)
+	ego.SliceList.Add(rel)
+	return ego
+}
+
+/*
+Deletes the elements at the specified positions in the collection.
+Overrides the method of the promoted field.
+
+Parameters:
+  - indexes... - any amount of positions of the elements to delete.
+
+Returns:
+  - updated list (promoted field).
+*/
+func (ego *relationshipCollection) Delete(indexes ...int) anytype.List {
+	for _, index := range indexes {
+		elem := ego.Get(index)
+		if ego.added.Contains(elem) {
+			ego.added.Delete(ego.added.IndexOf(elem))
+		} else {
+			rel, ok := elem.(*Relationship)
+			if ok && rel.id != "" {
+				ego.deleted.Add(rel.id)
+			}
+		}
+	}
+	return ego.SliceList.Delete(indexes...)
+}
+
+/*
+Commits all changes to the database in a single managed transaction.
+If the transaction fails, the whole batch of adds and deletes is rolled back
+and the tracking lists are left untouched so the commit can be retried.
+
+Returns:
+  - updated list (promoted field),
+  - error if any occurred.
+*/
+func (ego *relationshipCollection) Commit() (anytype.List, error) {
+
+	quotedKind, err := QuoteIdentifier(ego.kind)
+	if err != nil {
+		return ego.SliceList, err
+	}
+
+	err = ego.conn.WithTransaction(func(tx neo4j.ManagedTransaction) error {
+
+		var txErr error
+
+		// Commiting added relationships
+		if !ego.added.Empty() {
+			ego.added.ForEachObject(func(x anytype.Object) {
+				if txErr != nil {
+					return
+				}
+				rel := x.(*Relationship)
+				template, err := rel.template()
+				if err != nil {
+					txErr = err
+					return
+				}
+				query := `
+					MATCH (a) WHERE elementId(a) = $fromId
+					MATCH (b) WHERE elementId(b) = $toId
+					CREATE (a)-[r:` + quotedKind + template + `]->(b)
+					RETURN elementId(r)
+				`
+				var propKeys []string
+				rel.ForEach(func(key string, _ any) { propKeys = append(propKeys, key) })
+				params := rel.Pluck(propKeys...).Set("fromId", rel.fromId, "toId", rel.toId)
+				result, err := runCypher(ego.conn.ctx, tx.Run, query, params)
+				if err != nil {
+					txErr = err
+					return
+				}
+				rel.id = result.GetObject(0).GetString("elementId(r)")
+			})
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		// Commiting deleted relationships
+		if !ego.deleted.Empty() {
+			ego.deleted.ForEachString(func(id string) {
+				if txErr != nil {
+					return
+				}
+				_, txErr = runCypher(ego.conn.ctx, tx.Run, `
+					MATCH ()-[r]->()
+					WHERE elementId(r) = $id
+					DELETE r
+				`, anytype.NewObject("id", id))
+			})
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		return nil
+
+	})
+
+	if err != nil {
+		return ego.SliceList, err
+	}
+
+	ego.added.Clear()
+	ego.deleted.Clear()
+
+	return ego.SliceList, nil
+
+}
+
+/*
+Gets the nodes of the given collection related to n by a relationship of the given type.
+
+Parameters:
+  - n - node to start the traversal from,
+  - kind - type of the relationship,
+  - target - collection the related nodes belong to,
+  - direction - direction of the traversal.
+
+Returns:
+  - list of the related nodes,
+  - error if any occurred.
+*/
+func (ego *collection) Related(n *Node, kind string, target Collection, direction Direction) (anytype.List, error) {
+
+	targetCol := target.(*collection)
+
+	quotedKind, err := QuoteIdentifier(kind)
+	if err != nil {
+		return nil, err
+	}
+	targetLabel, err := QuoteIdentifier(targetCol.label)
+	if err != nil {
+		return nil, err
+	}
+
+	var pattern string
+	switch direction {
+	case Outgoing:
+		pattern = `(a)-[r:` + quotedKind + `]->(b:` + targetLabel + `)`
+	case Incoming:
+		pattern = `(a)<-[r:` + quotedKind + `]-(b:` + targetLabel + `)`
+	default:
+		pattern = `(a)-[r:` + quotedKind + `]-(b:` + targetLabel + `)`
+	}
+
+	query := `MATCH (a) WHERE elementId(a) = $id MATCH ` + pattern + ` RETURN b`
+	result, err := ego.conn.Query(query, anytype.NewObject("id", n.id))
+	if err != nil {
+		return nil, err
+	}
+
+	return result.MapObjects(func(x anytype.Object) any {
+		return targetCol.newNode(x.GetObject("b").GetString("elementId"),
+			x.GetObject("b").GetObject("properties").(*anytype.MapObject))
+	}), nil
+
+}
@@ -0,0 +1,42 @@
+package neo4go
+
+import (
+	"fmt"
+	"regexp"
+)
+
+/*
+Pattern a Cypher identifier (a node label, relationship type or property key) has to match
+to be safely interpolated into a query. Requires the identifier to start with a Unicode
+letter or an underscore, followed by any amount of Unicode letters, digits or underscores,
+which rules out backticks, quotes, whitespace and statement separators used in injection attempts.
+
+Property keys double as bare `$paramName` query parameter placeholders (see Node.commitTx),
+and Cypher parameter names cannot contain whitespace, quotes or backticks either, so this
+regex deliberately rejects those instead of trying to make them safe by backtick-quoting:
+a key accepted here is guaranteed to be valid both as a backtick-quoted identifier and as a
+bare parameter name. Unicode letters are allowed, since Cypher identifiers support them.
+*/
+var cypherIdentifierPattern = regexp.MustCompile(`^[\p{L}_][\p{L}\p{N}_]*$`)
+
+/*
+Validates a Cypher identifier and wraps it in backticks so it can be interpolated into a query
+regardless of whether it happens to be a reserved word. Identifiers containing whitespace,
+quotes or backticks are rejected rather than escaped, see cypherIdentifierPattern.
+
+Exported so packages building their own Cypher around user-supplied labels or types, such as
+migrate, can validate them the same way instead of reimplementing the check.
+
+Parameters:
+  - identifier - label, relationship type or property key to validate.
+
+Returns:
+  - backtick-quoted identifier,
+  - error if the identifier is not a valid Cypher identifier.
+*/
+func QuoteIdentifier(identifier string) (string, error) {
+	if !cypherIdentifierPattern.MatchString(identifier) {
+		return "", fmt.Errorf("neo4go: %q is not a valid Cypher identifier", identifier)
+	}
+	return "`" + identifier + "`", nil
+}
@@ -0,0 +1,41 @@
+package neo4go
+
+import "testing"
+
+func TestQuoteIdentifierValid(t *testing.T) {
+	cases := []string{
+		"Person",
+		"_private",
+		"naïve",
+		"uživatel",
+		"名前",
+		"a1_2",
+	}
+	for _, identifier := range cases {
+		quoted, err := QuoteIdentifier(identifier)
+		if err != nil {
+			t.Errorf("QuoteIdentifier(%q) returned unexpected error: %v", identifier, err)
+			continue
+		}
+		if expected := "`" + identifier + "`"; quoted != expected {
+			t.Errorf("QuoteIdentifier(%q) = %q, want %q", identifier, quoted, expected)
+		}
+	}
+}
+
+func TestQuoteIdentifierInvalid(t *testing.T) {
+	cases := []string{
+		"",
+		"with space",
+		`with"quote`,
+		"with'quote",
+		"with`backtick",
+		"1leadingDigit",
+		"a; DROP DATABASE neo4j",
+	}
+	for _, identifier := range cases {
+		if _, err := QuoteIdentifier(identifier); err == nil {
+			t.Errorf("QuoteIdentifier(%q) expected an error, got none", identifier)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package neo4go
+
+import (
+	"context"
+	"strings"
+
+	"github.com/DanielSvub/anytype"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+/*
+Interface for lazily iterating over the records of a query, pulling them from the driver
+one record at a time instead of materializing the whole result in memory.
+*/
+type ResultIterator interface {
+	// Advances to the next record, fetching it from the driver. Returns false once the
+	// result is exhausted or an error occurred; check Err afterwards to tell the two apart.
+	Next() bool
+	// Returns the record last advanced to by Next.
+	Record() anytype.Object
+	// Returns the error that stopped iteration, if any.
+	Err() error
+	// Discards any remaining records and releases the underlying result.
+	Close() error
+}
+
+/*
+Default ResultIterator implementation, pulling records from a neo4j.ResultWithContext.
+*/
+type resultIterator struct {
+	ctx    context.Context
+	result neo4j.ResultWithContext
+	record anytype.Object
+	err    error
+}
+
+/*
+Performs a query over the Neo4j database, returning an iterator over its results instead of
+draining them into memory upfront.
+
+Parameters:
+  - ctx - context the query is bound to,
+  - query - text of the query in Cypher,
+  - params - object containing variables used in the query.
+
+Returns:
+  - iterator over the query results,
+  - error if any occurred.
+*/
+func (ego *connection) QueryStream(ctx context.Context, query string, params anytype.Object) (ResultIterator, error) {
+
+	var paramDict map[string]any
+	if params != nil {
+		paramDict = params.Dict()
+	}
+
+	query = strings.TrimSpace(query)
+
+	result, err := ego.session.Run(ctx, query, paramDict)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resultIterator{ctx: ctx, result: result}, nil
+
+}
+
+/*
+Advances to the next record, converting it the same way Query does.
+
+Returns:
+  - whether a record is available.
+*/
+func (ego *resultIterator) Next() bool {
+
+	if !ego.result.Next(ego.ctx) {
+		ego.err = ego.result.Err()
+		return false
+	}
+
+	record := ego.result.Record()
+	item := anytype.NewObject()
+	for _, key := range record.Keys {
+		value, ok := record.Get(key)
+		if ok {
+			item.Set(key, convertValue(value))
+		}
+	}
+	ego.record = item
+
+	return true
+
+}
+
+/*
+Returns the record last advanced to by Next.
+
+Returns:
+  - current record.
+*/
+func (ego *resultIterator) Record() anytype.Object {
+	return ego.record
+}
+
+/*
+Returns the error that stopped iteration, if any.
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *resultIterator) Err() error {
+	return ego.err
+}
+
+/*
+Discards any remaining records and releases the underlying result.
+
+Returns:
+  - error if any occurred.
+*/
+func (ego *resultIterator) Close() error {
+	_, err := ego.result.Consume(ego.ctx)
+	return err
+}
+
+/*
+Performs a query over the Neo4j database and streams its results over a channel, closing it
+once the result is exhausted or an error occurs. Intended for pipeline-style consumption.
+
+A mid-stream driver failure truncates the records channel the same way a clean end of result
+would; read errs after the records channel closes to tell the two apart, instead of assuming
+a closed channel means every record arrived.
+
+Parameters:
+  - ctx - context the query is bound to,
+  - query - text of the query in Cypher,
+  - params - object containing variables used in the query.
+
+Returns:
+  - channel yielding the converted records, closed once the query is done,
+  - channel yielding at most one error, closed once the query is done,
+  - error if any occurred.
+*/
+func (ego *connection) QueryChan(ctx context.Context, query string, params anytype.Object) (<-chan anytype.Object, <-chan error, error) {
+
+	it, err := ego.QueryStream(ctx, query, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := make(chan anytype.Object)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(out)
+		defer close(errs)
+		defer it.Close()
+		for it.Next() {
+			out <- it.Record()
+		}
+		if err := it.Err(); err != nil {
+			errs <- err
+		}
+	}()
+
+	return out, errs, nil
+
+}
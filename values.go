@@ -0,0 +1,146 @@
+package neo4go
+
+import (
+	"time"
+
+	"github.com/DanielSvub/anytype"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+)
+
+/*
+Interface for converting a value returned by the driver into something easily consumable
+via anytype, for types neo4go does not already handle natively.
+*/
+type ValueConverter interface {
+	// Reports whether this converter handles the given value.
+	CanConvert(value any) bool
+	// Converts the value into an anytype-friendly representation.
+	Convert(value any) any
+}
+
+// Custom converters registered via RegisterValueConverter, consulted in LIFO order.
+var valueConverters []ValueConverter
+
+/*
+Registers a converter consulted for values not covered by neo4go's built-in conversions
+(nodes, relationships, paths, spatial and temporal types). Converters registered later are
+tried first.
+
+Parameters:
+  - converter - converter to register.
+*/
+func RegisterValueConverter(converter ValueConverter) {
+	valueConverters = append([]ValueConverter{converter}, valueConverters...)
+}
+
+/*
+Converts a node returned by the driver into its anytype representation.
+
+Parameters:
+  - val - node to convert.
+
+Returns:
+  - converted node.
+*/
+func convertNode(val dbtype.Node) anytype.Object {
+	return anytype.NewObject(
+		"identity", val.GetId(),
+		"elementId", val.GetElementId(),
+		"labels", val.Labels,
+		"properties", val.GetProperties(),
+	)
+}
+
+/*
+Converts a relationship returned by the driver into its anytype representation.
+
+Parameters:
+  - val - relationship to convert.
+
+Returns:
+  - converted relationship.
+*/
+func convertRelationship(val dbtype.Relationship) anytype.Object {
+	return anytype.NewObject(
+		"identity", val.GetId(),
+		"elementId", val.GetElementId(),
+		"type", val.Type,
+		"startElementId", val.StartElementId,
+		"endElementId", val.EndElementId,
+		"properties", val.GetProperties(),
+	)
+}
+
+/*
+Converts a path returned by the driver into its anytype representation.
+
+Parameters:
+  - val - path to convert.
+
+Returns:
+  - converted path.
+*/
+func convertPath(val dbtype.Path) anytype.Object {
+
+	nodes := anytype.NewList()
+	for _, n := range val.Nodes {
+		nodes.Add(convertNode(n))
+	}
+
+	relationships := anytype.NewList()
+	for _, r := range val.Relationships {
+		relationships.Add(convertRelationship(r))
+	}
+
+	return anytype.NewObject("nodes", nodes, "relationships", relationships)
+
+}
+
+/*
+Converts a value returned by the driver into something easily consumable via anytype.
+Nodes, relationships, paths and spatial points are unwrapped into plain anytype objects,
+dates and times into time.Time, and durations into their string form; time.Time and []byte
+values are returned as they are. Anything else is passed through RegisterValueConverter
+converters, falling back to the raw driver value if none claims it.
+
+Parameters:
+  - value - value to convert.
+
+Returns:
+  - converted value.
+*/
+func convertValue(value any) any {
+	switch val := value.(type) {
+	case dbtype.Node:
+		return convertNode(val)
+	case dbtype.Relationship:
+		return convertRelationship(val)
+	case dbtype.Path:
+		return convertPath(val)
+	case dbtype.Point2D:
+		return anytype.NewObject("srid", val.SpatialRefId, "x", val.X, "y", val.Y)
+	case dbtype.Point3D:
+		return anytype.NewObject("srid", val.SpatialRefId, "x", val.X, "y", val.Y, "z", val.Z)
+	case time.Time:
+		return val
+	case dbtype.Date:
+		return val.Time()
+	case dbtype.LocalDateTime:
+		return val.Time()
+	case dbtype.LocalTime:
+		return val.Time()
+	case dbtype.Time:
+		return val.Time()
+	case dbtype.Duration:
+		return val.String()
+	case []byte:
+		return val
+	default:
+		for _, converter := range valueConverters {
+			if converter.CanConvert(value) {
+				return converter.Convert(value)
+			}
+		}
+		return value
+	}
+}